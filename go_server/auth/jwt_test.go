@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssueAndParseToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	token, err := IssueToken("alice", "admin", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	claims, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+	if claims.Sub != "alice" || claims.Role != "admin" {
+		t.Errorf("claims = %+v, want sub=alice role=admin", claims)
+	}
+}
+
+func TestParseToken_Expired(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	token, err := IssueToken("alice", "admin", -time.Minute)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	if _, err := ParseToken(token); err != ErrExpiredToken {
+		t.Errorf("ParseToken() error = %v, want ErrExpiredToken", err)
+	}
+}
+
+func TestParseToken_TamperedSignature(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	token, err := IssueToken("alice", "public", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	tampered := token[:len(token)-1] + "x"
+	if _, err := ParseToken(tampered); err != ErrInvalidToken {
+		t.Errorf("ParseToken() error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestParseToken_RejectsTokenSignedWithDifferentSecret(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	token, err := IssueToken("alice", "admin", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	t.Setenv("JWT_SECRET", "a-different-secret")
+	if _, err := ParseToken(token); err != ErrInvalidToken {
+		t.Errorf("ParseToken() error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestRequireSecret(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	if err := RequireSecret(); err != ErrMissingSecret {
+		t.Errorf("RequireSecret() error = %v, want ErrMissingSecret", err)
+	}
+
+	t.Setenv("JWT_SECRET", "set")
+	if err := RequireSecret(); err != nil {
+		t.Errorf("RequireSecret() error = %v, want nil", err)
+	}
+}
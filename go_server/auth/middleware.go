@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go_server/utils"
+)
+
+type claimsKey struct{}
+
+// ClaimsFromContext returns the claims stored by RequireAuth, or nil if the
+// request wasn't authenticated
+func ClaimsFromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(claimsKey{}).(*Claims)
+	return claims
+}
+
+// RequireAuth returns middleware that rejects requests without a valid
+// bearer token, or whose role isn't one of the allowed roles. An empty
+// roles list only requires a valid token, regardless of role. On success the
+// parsed claims are stored in the request context.
+func RequireAuth(roles ...string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				utils.WriteError(w, http.StatusUnauthorized, "missing_token", "Missing bearer token", nil)
+				return
+			}
+
+			claims, err := ParseToken(token)
+			if err != nil {
+				msg := "Invalid token"
+				if errors.Is(err, ErrExpiredToken) {
+					msg = "Token expired"
+				}
+				utils.WriteError(w, http.StatusUnauthorized, "invalid_token", msg, nil)
+				return
+			}
+
+			if len(roles) > 0 && !hasRole(claims.Role, roles) {
+				utils.WriteError(w, http.StatusForbidden, "forbidden", "Insufficient permissions", nil)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), claimsKey{}, claims)
+			next(w, r.WithContext(ctx))
+		}
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(header, prefix), true
+}
+
+func hasRole(role string, allowed []string) bool {
+	for _, a := range allowed {
+		if role == a {
+			return true
+		}
+	}
+	return false
+}
+
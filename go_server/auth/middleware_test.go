@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequireAuth_MissingToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	handler := RequireAuth()(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run without a token")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireAuth_InvalidToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	handler := RequireAuth()(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run with an invalid token")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer not-a-real-token")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireAuth_InsufficientRole(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	token, err := IssueToken("alice", "public", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	handler := RequireAuth("staff", "admin")(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run with an insufficient role")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestRequireAuth_Success(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	token, err := IssueToken("bob", "admin", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	var gotClaims *Claims
+	handler := RequireAuth("staff", "admin")(func(w http.ResponseWriter, r *http.Request) {
+		gotClaims = ClaimsFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if gotClaims == nil || gotClaims.Sub != "bob" {
+		t.Errorf("claims = %+v, want sub=bob", gotClaims)
+	}
+}
@@ -0,0 +1,70 @@
+// Command migrate manages the dog shelter database's schema.
+//
+// Usage:
+//
+//	go run ./cmd/migrate up       # apply all pending migrations
+//	go run ./cmd/migrate down     # revert the most recently applied migration
+//	go run ./cmd/migrate status   # list migrations and whether they're applied
+//	go run ./cmd/migrate seed     # populate sample breeds and dogs
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/go_server/models"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: migrate <up|down|status|seed>")
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := models.OpenDB(); err != nil {
+		log.Fatalf("database initialization failed: %v", err)
+	}
+
+	ctx := context.Background()
+
+	switch flag.Arg(0) {
+	case "up":
+		if err := models.Migrate(ctx, models.DB); err != nil {
+			log.Fatalf("migrate up failed: %v", err)
+		}
+		fmt.Println("migrations applied")
+	case "down":
+		if err := models.MigrateDown(ctx, models.DB); err != nil {
+			log.Fatalf("migrate down failed: %v", err)
+		}
+		fmt.Println("last migration reverted")
+	case "status":
+		statuses, err := models.MigrationStatuses(ctx, models.DB)
+		if err != nil {
+			log.Fatalf("migrate status failed: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%04d_%s: %s\n", s.Version, s.Name, state)
+		}
+	case "seed":
+		if err := models.Seed(ctx, models.DB); err != nil {
+			log.Fatalf("seed failed: %v", err)
+		}
+		fmt.Println("database seeded")
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go_server/models"
+	"github.com/go_server/utils"
+)
+
+// adoptionRequest is the expected body for the adopt and reserve endpoints
+type adoptionRequest struct {
+	Adopter models.Adopter `json:"adopter"`
+}
+
+// ReserveDogHandler handles POST /api/dogs/{id}/reserve, moving a dog from
+// Available to Pending on behalf of a prospective adopter
+func ReserveDogHandler(w http.ResponseWriter, r *http.Request) {
+	transitionDogStatus(w, r, models.Available, models.Pending)
+}
+
+// AdoptDogHandler handles POST /api/dogs/{id}/adopt, finalizing a pending
+// reservation and recording the adoption date
+func AdoptDogHandler(w http.ResponseWriter, r *http.Request) {
+	transitionDogStatus(w, r, models.Pending, models.Adopted)
+}
+
+// ReturnDogHandler handles POST /api/dogs/{id}/return, putting a dog back up
+// for adoption from either Pending or Adopted
+func ReturnDogHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := dogIDFromRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid dog ID", nil)
+		return
+	}
+
+	dog, err := models.GetDogByID(id)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error retrieving dog: "+err.Error(), nil)
+		return
+	}
+	if dog == nil {
+		writeDogNotFound(w)
+		return
+	}
+
+	updated, err := models.UpdateDogStatus(id, models.AdoptionStatus(dog.Status), models.Available, nil)
+	writeTransitionResult(w, updated, err)
+}
+
+// transitionDogStatus parses the adopter from the request body and applies
+// the from->to transition via models.UpdateDogStatus
+func transitionDogStatus(w http.ResponseWriter, r *http.Request, from, to models.AdoptionStatus) {
+	id, err := dogIDFromRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid dog ID", nil)
+		return
+	}
+
+	var req adoptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
+		return
+	}
+
+	updated, err := models.UpdateDogStatus(id, from, to, &req.Adopter)
+	writeTransitionResult(w, updated, err)
+}
+
+// writeTransitionResult maps a models.UpdateDogStatus result to an HTTP response
+func writeTransitionResult(w http.ResponseWriter, dog *models.DogResponse, err error) {
+	if errors.Is(err, models.ErrDogNotFound) {
+		writeDogNotFound(w)
+		return
+	}
+	if errors.Is(err, models.ErrInvalidTransition) {
+		utils.WriteError(w, http.StatusConflict, "invalid_transition", "Invalid adoption status transition", nil)
+		return
+	}
+	if errors.Is(err, models.ErrConcurrentUpdate) {
+		utils.WriteError(w, http.StatusConflict, "concurrent_update", "Dog status changed concurrently, please retry", nil)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error updating dog status: "+err.Error(), nil)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, dog)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go_server/auth"
+	"github.com/go_server/models"
+	"github.com/go_server/utils"
+)
+
+// registerRequest is the expected body for POST /api/auth/register
+type registerRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// loginRequest is the expected body for POST /api/auth/login
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ValidationErrors returns field-level validation errors for a registration
+// request, keyed by JSON field name. An empty map means the request is valid.
+func (req registerRequest) ValidationErrors() map[string]string {
+	errs := map[string]string{}
+
+	if req.Username == "" {
+		errs["username"] = "username is required"
+	} else if len(req.Username) < 3 {
+		errs["username"] = "username must be at least 3 characters"
+	}
+
+	if req.Email == "" {
+		errs["email"] = "email is required"
+	} else if !strings.Contains(req.Email, "@") {
+		errs["email"] = "email must be a valid email address"
+	}
+
+	if req.Password == "" {
+		errs["password"] = "password is required"
+	} else if len(req.Password) < 8 {
+		errs["password"] = "password must be at least 8 characters"
+	}
+
+	return errs
+}
+
+// tokenResponse is returned by both register and login on success
+type tokenResponse struct {
+	Token string      `json:"token"`
+	User  models.User `json:"user"`
+}
+
+// RegisterHandler handles POST /api/auth/register, creating a public account
+// and returning a signed token for it
+func RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	var req registerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
+		return
+	}
+
+	if fields := req.ValidationErrors(); len(fields) > 0 {
+		utils.WriteError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed", fields)
+		return
+	}
+
+	user, err := models.CreateUser(req.Username, req.Email, req.Password, models.RolePublic)
+	if errors.Is(err, models.ErrUserExists) {
+		utils.WriteError(w, http.StatusConflict, "user_exists", "Username or email already registered", nil)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error creating user: "+err.Error(), nil)
+		return
+	}
+
+	issueAndWriteToken(w, user)
+}
+
+// LoginHandler handles POST /api/auth/login, returning a signed token for a
+// valid username/password pair
+func LoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
+		return
+	}
+
+	user, err := models.Authenticate(req.Username, req.Password)
+	if errors.Is(err, models.ErrInvalidCredentials) {
+		utils.WriteError(w, http.StatusUnauthorized, "invalid_credentials", "Invalid username or password", nil)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error authenticating user: "+err.Error(), nil)
+		return
+	}
+
+	issueAndWriteToken(w, user)
+}
+
+func issueAndWriteToken(w http.ResponseWriter, user *models.User) {
+	token, err := auth.IssueToken(user.Username, string(user.Role), auth.DefaultTTL)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error issuing token: "+err.Error(), nil)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, tokenResponse{Token: token, User: *user})
+}
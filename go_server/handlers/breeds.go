@@ -3,31 +3,161 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/go_server/models"
+	"github.com/go_server/router"
+	"github.com/go_server/utils"
 )
 
 // GetBreedsHandler handles the GET request to retrieve all breeds
 func GetBreedsHandler(w http.ResponseWriter, r *http.Request) {
-	// Only allow GET requests
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	breeds, err := models.GetBreeds()
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error retrieving breeds: "+err.Error(), nil)
 		return
 	}
 
-	// Get breeds from database
-	breeds, err := models.GetBreeds()
+	utils.JSONResponse(w, http.StatusOK, breeds)
+}
+
+// CreateBreedHandler handles POST /api/breeds
+func CreateBreedHandler(w http.ResponseWriter, r *http.Request) {
+	var breed models.Breed
+	if err := json.NewDecoder(r.Body).Decode(&breed); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
+		return
+	}
+
+	if fields := breed.ValidationErrors(); len(fields) > 0 {
+		utils.WriteError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed", fields)
+		return
+	}
+
+	created, err := models.CreateBreed(&breed)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error creating breed: "+err.Error(), nil)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, created)
+}
+
+// UpdateBreedHandler handles PUT /api/breeds/{id}
+func UpdateBreedHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := breedIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Error retrieving breeds: "+err.Error(), http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid breed ID", nil)
 		return
 	}
 
-	// Set content type to JSON
-	w.Header().Set("Content-Type", "application/json")
-	
-	// Write response
-	if err := json.NewEncoder(w).Encode(breeds); err != nil {
-		http.Error(w, "Error encoding response: "+err.Error(), http.StatusInternalServerError)
+	var breed models.Breed
+	if err := json.NewDecoder(r.Body).Decode(&breed); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
 		return
 	}
-}
\ No newline at end of file
+
+	if fields := breed.ValidationErrors(); len(fields) > 0 {
+		utils.WriteError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed", fields)
+		return
+	}
+
+	updated, err := models.UpdateBreed(id, &breed)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error updating breed: "+err.Error(), nil)
+		return
+	}
+	if updated == nil {
+		writeBreedNotFound(w)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, updated)
+}
+
+// PatchBreedHandler handles PATCH /api/breeds/{id}
+func PatchBreedHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := breedIDFromRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid breed ID", nil)
+		return
+	}
+
+	var patch models.BreedPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
+		return
+	}
+
+	current, err := models.GetBreedRaw(id)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error retrieving breed: "+err.Error(), nil)
+		return
+	}
+	if current == nil {
+		writeBreedNotFound(w)
+		return
+	}
+
+	merged := applyBreedPatch(*current, patch)
+	if fields := merged.ValidationErrors(); len(fields) > 0 {
+		utils.WriteError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed", fields)
+		return
+	}
+
+	updated, err := models.PatchBreed(id, patch)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error updating breed: "+err.Error(), nil)
+		return
+	}
+	if updated == nil {
+		writeBreedNotFound(w)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, updated)
+}
+
+// DeleteBreedHandler handles DELETE /api/breeds/{id}
+func DeleteBreedHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := breedIDFromRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid breed ID", nil)
+		return
+	}
+
+	if err := models.DeleteBreed(id); err != nil {
+		if err == models.ErrBreedNotFound {
+			writeBreedNotFound(w)
+			return
+		}
+		if err == models.ErrBreedInUse {
+			utils.WriteError(w, http.StatusConflict, "breed_in_use", "Breed is still referenced by existing dogs", nil)
+			return
+		}
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error deleting breed: "+err.Error(), nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyBreedPatch merges patch's non-nil fields onto current, for validating
+// the result of a PATCH before it's applied
+func applyBreedPatch(current models.Breed, patch models.BreedPatch) models.Breed {
+	if patch.Name != nil {
+		current.Name = *patch.Name
+	}
+	if patch.Description != nil {
+		current.Description = *patch.Description
+	}
+	return current
+}
+
+func breedIDFromRequest(r *http.Request) (int, error) {
+	return strconv.Atoi(router.Vars(r)["id"])
+}
+
+func writeBreedNotFound(w http.ResponseWriter) {
+	utils.WriteError(w, http.StatusNotFound, "not_found", "Breed not found", nil)
+}
@@ -2,92 +2,310 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/go_server/models"
+	"github.com/go_server/router"
+	"github.com/go_server/utils"
 )
 
-// GetDogsHandler handles the GET request to retrieve all dogs
-func GetDogsHandler(w http.ResponseWriter, r *http.Request) {
-	// Only allow GET requests
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+const (
+	defaultDogsLimit = 20
+	maxDogsLimit     = 100
+)
 
+// GetDogsHandler handles the GET request to retrieve a page of dogs
+func GetDogsHandler(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	queryParams := r.URL.Query()
-	
+
 	// Get breedID parameter if provided
 	var breedID *int
 	if breedIDStr := queryParams.Get("breed_id"); breedIDStr != "" {
 		id, err := strconv.Atoi(breedIDStr)
 		if err != nil {
-			http.Error(w, "Invalid breed_id parameter", http.StatusBadRequest)
+			utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid breed_id parameter", nil)
 			return
 		}
 		breedID = &id
 	}
-	
+
 	// Get available parameter if provided
 	availableOnly := queryParams.Get("available") == "true"
 
-	// Get dogs from database
-	dogs, err := models.GetDogs(breedID, availableOnly)
+	limit, offset, err := parsePagination(queryParams, defaultDogsLimit, maxDogsLimit)
 	if err != nil {
-		http.Error(w, "Error retrieving dogs: "+err.Error(), http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", err.Error(), nil)
 		return
 	}
 
-	// Set content type to JSON
-	w.Header().Set("Content-Type", "application/json")
-	
-	// Write response
-	if err := json.NewEncoder(w).Encode(dogs); err != nil {
-		http.Error(w, "Error encoding response: "+err.Error(), http.StatusInternalServerError)
+	// Get dogs from database
+	dogs, total, err := models.GetDogs(breedID, availableOnly, limit, offset)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error retrieving dogs: "+err.Error(), nil)
 		return
 	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if link := buildLinkHeader(r.URL, limit, offset, total); link != "" {
+		w.Header().Set("Link", link)
+	}
+
+	utils.JSONResponse(w, http.StatusOK, dogs)
 }
 
 // GetDogByIDHandler handles the GET request to retrieve a specific dog by ID
 func GetDogByIDHandler(w http.ResponseWriter, r *http.Request) {
-	// Only allow GET requests
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	id, err := dogIDFromRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid dog ID", nil)
 		return
 	}
 
-	// Extract dog ID from URL path
-	// Expected format: /api/dogs/{id}
-	idStr := r.URL.Path[len("/api/dogs/"):]
-	id, err := strconv.Atoi(idStr)
+	dog, err := models.GetDogByID(id)
 	if err != nil {
-		http.Error(w, "Invalid dog ID", http.StatusBadRequest)
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error retrieving dog: "+err.Error(), nil)
 		return
 	}
 
-	// Get dog from database
-	dog, err := models.GetDogByID(id)
+	if dog == nil {
+		writeDogNotFound(w)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, dog)
+}
+
+// CreateDogHandler handles POST /api/dogs
+func CreateDogHandler(w http.ResponseWriter, r *http.Request) {
+	var dog models.Dog
+	if err := json.NewDecoder(r.Body).Decode(&dog); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
+		return
+	}
+
+	if fields := dog.ValidationErrors(); len(fields) > 0 {
+		utils.WriteError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed", fields)
+		return
+	}
+
+	created, err := models.CreateDog(&dog)
+	if err == models.ErrBreedNotFound {
+		writeBreedIDValidationError(w)
+		return
+	}
 	if err != nil {
-		http.Error(w, "Error retrieving dog: "+err.Error(), http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error creating dog: "+err.Error(), nil)
 		return
 	}
 
-	// Check if dog exists
-	if dog == nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(models.ErrorResponse{Error: "Dog not found"})
+	utils.JSONResponse(w, http.StatusCreated, created)
+}
+
+// UpdateDogHandler handles PUT /api/dogs/{id}
+func UpdateDogHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := dogIDFromRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid dog ID", nil)
+		return
+	}
+
+	var dog models.Dog
+	if err := json.NewDecoder(r.Body).Decode(&dog); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
+		return
+	}
+
+	if fields := dog.ValidationErrors(); len(fields) > 0 {
+		utils.WriteError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed", fields)
+		return
+	}
+
+	updated, err := models.UpdateDog(id, &dog)
+	if err == models.ErrBreedNotFound {
+		writeBreedIDValidationError(w)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error updating dog: "+err.Error(), nil)
+		return
+	}
+	if updated == nil {
+		writeDogNotFound(w)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, updated)
+}
+
+// PatchDogHandler handles PATCH /api/dogs/{id}
+func PatchDogHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := dogIDFromRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid dog ID", nil)
 		return
 	}
 
-	// Set content type to JSON
-	w.Header().Set("Content-Type", "application/json")
-	
-	// Write response
-	if err := json.NewEncoder(w).Encode(dog); err != nil {
-		http.Error(w, "Error encoding response: "+err.Error(), http.StatusInternalServerError)
+	var patch models.DogPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_body", "Invalid request body", nil)
 		return
 	}
-}
\ No newline at end of file
+
+	current, err := models.GetDogRaw(id)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error retrieving dog: "+err.Error(), nil)
+		return
+	}
+	if current == nil {
+		writeDogNotFound(w)
+		return
+	}
+
+	merged := applyDogPatch(*current, patch)
+	if fields := merged.ValidationErrors(); len(fields) > 0 {
+		utils.WriteError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed", fields)
+		return
+	}
+
+	updated, err := models.PatchDog(id, patch)
+	if err == models.ErrBreedNotFound {
+		writeBreedIDValidationError(w)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error updating dog: "+err.Error(), nil)
+		return
+	}
+	if updated == nil {
+		writeDogNotFound(w)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, updated)
+}
+
+// applyDogPatch merges patch's non-nil fields onto current, for validating
+// the result of a PATCH before it's applied
+func applyDogPatch(current models.Dog, patch models.DogPatch) models.Dog {
+	if patch.Name != nil {
+		current.Name = *patch.Name
+	}
+	if patch.BreedID != nil {
+		current.BreedID = *patch.BreedID
+	}
+	if patch.Age != nil {
+		current.Age = *patch.Age
+	}
+	if patch.Gender != nil {
+		current.Gender = *patch.Gender
+	}
+	if patch.Description != nil {
+		current.Description = *patch.Description
+	}
+	return current
+}
+
+// DeleteDogHandler handles DELETE /api/dogs/{id}
+func DeleteDogHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := dogIDFromRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid_parameter", "Invalid dog ID", nil)
+		return
+	}
+
+	if err := models.DeleteDog(id); err != nil {
+		if err == models.ErrDogNotFound {
+			writeDogNotFound(w)
+			return
+		}
+		utils.WriteError(w, http.StatusInternalServerError, "internal_error", "Error deleting dog: "+err.Error(), nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func dogIDFromRequest(r *http.Request) (int, error) {
+	return strconv.Atoi(router.Vars(r)["id"])
+}
+
+func writeDogNotFound(w http.ResponseWriter) {
+	utils.WriteError(w, http.StatusNotFound, "not_found", "Dog not found", nil)
+}
+
+func writeBreedIDValidationError(w http.ResponseWriter) {
+	utils.WriteError(w, http.StatusUnprocessableEntity, "validation_error", "Validation failed",
+		map[string]string{"breed_id": "breed does not exist"})
+}
+
+// parsePagination reads limit/offset query parameters, applying the given
+// default and maximum limit
+func parsePagination(q map[string][]string, defaultLimit, maxLimit int) (limit, offset int, err error) {
+	limit = defaultLimit
+	if v := firstOrEmpty(q, "limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return 0, 0, fmt.Errorf("invalid limit parameter")
+		}
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+	}
+
+	offset = 0
+	if v := firstOrEmpty(q, "offset"); v != "" {
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid offset parameter")
+		}
+	}
+
+	return limit, offset, nil
+}
+
+func firstOrEmpty(q map[string][]string, key string) string {
+	if vals, ok := q[key]; ok && len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// buildLinkHeader builds an RFC 5988 Link header advertising the next and
+// previous pages, if any
+func buildLinkHeader(u *url.URL, limit, offset, total int) string {
+	links := []string{}
+
+	if offset+limit < total {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(u, limit, offset+limit)))
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(u, limit, prevOffset)))
+	}
+
+	if len(links) == 0 {
+		return ""
+	}
+
+	out := links[0]
+	for _, l := range links[1:] {
+		out += ", " + l
+	}
+	return out
+}
+
+func pageURL(u *url.URL, limit, offset int) string {
+	next := *u
+	q := next.Query()
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("offset", strconv.Itoa(offset))
+	next.RawQuery = q.Encode()
+	return next.String()
+}
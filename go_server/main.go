@@ -5,55 +5,104 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"strings"
+	"time"
 
+	"github.com/go_server/auth"
 	"github.com/go_server/handlers"
+	"github.com/go_server/metrics"
 	"github.com/go_server/models"
+	"github.com/go_server/router"
+	"github.com/go_server/utils"
 )
 
-// setupRoutes sets up the HTTP routes
+// dogsByStatusSampleInterval controls how often dogs_by_status is refreshed
+const dogsByStatusSampleInterval = 30 * time.Second
+
+// setupRoutes sets up the HTTP routes and middleware chain
 func setupRoutes() http.Handler {
-	mux := http.NewServeMux()
-
-	// API endpoints
-	mux.HandleFunc("/api/dogs", handlers.GetDogsHandler)
-	mux.HandleFunc("/api/breeds", handlers.GetBreedsHandler)
-	
-	// Dog details endpoint with dynamic ID
-	mux.HandleFunc("/api/dogs/", func(w http.ResponseWriter, r *http.Request) {
-		// Check if the path has the format "/api/dogs/{id}"
-		path := r.URL.Path
-		if path == "/api/dogs/" {
-			http.NotFound(w, r)
-			return
-		}
-		
-		handlers.GetDogByIDHandler(w, r)
+	rt := router.New()
+
+	rt.Use(
+		utils.RequestIDMiddleware,
+		utils.RecoveryMiddleware,
+		utils.LoggingMiddleware,
+		utils.MetricsMiddleware,
+		utils.CORSMiddleware,
+		utils.ContentNegotiationMiddleware,
+	)
+
+	// Observability
+	rt.Get("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		metrics.Handler().ServeHTTP(w, r)
 	})
 
-	return mux
+	// Auth
+	rt.Post("/api/auth/register", handlers.RegisterHandler)
+	rt.Post("/api/auth/login", handlers.LoginHandler)
+
+	// Dogs
+	staffOrAdmin := auth.RequireAuth(string(models.RoleStaff), string(models.RoleAdmin))
+	rt.Get("/api/dogs", handlers.GetDogsHandler)
+	rt.Get("/api/dogs/{id}", handlers.GetDogByIDHandler)
+	rt.Post("/api/dogs", staffOrAdmin(handlers.CreateDogHandler))
+	rt.Put("/api/dogs/{id}", staffOrAdmin(handlers.UpdateDogHandler))
+	rt.Patch("/api/dogs/{id}", staffOrAdmin(handlers.PatchDogHandler))
+	rt.Delete("/api/dogs/{id}", staffOrAdmin(handlers.DeleteDogHandler))
+	rt.Post("/api/dogs/{id}/adopt", staffOrAdmin(handlers.AdoptDogHandler))
+	rt.Post("/api/dogs/{id}/reserve", staffOrAdmin(handlers.ReserveDogHandler))
+	rt.Post("/api/dogs/{id}/return", staffOrAdmin(handlers.ReturnDogHandler))
+
+	// Breeds
+	rt.Get("/api/breeds", handlers.GetBreedsHandler)
+	rt.Post("/api/breeds", staffOrAdmin(handlers.CreateBreedHandler))
+	rt.Put("/api/breeds/{id}", staffOrAdmin(handlers.UpdateBreedHandler))
+	rt.Patch("/api/breeds/{id}", staffOrAdmin(handlers.PatchBreedHandler))
+	rt.Delete("/api/breeds/{id}", staffOrAdmin(handlers.DeleteBreedHandler))
+
+	return rt
 }
 
 func main() {
+	// Refuse to start with a guessable (empty) JWT signing key
+	if err := auth.RequireSecret(); err != nil {
+		log.Fatalf("Startup check failed: %v", err)
+	}
+
 	// Initialize database connection
 	if err := models.InitDB(); err != nil {
 		log.Fatalf("Database initialization failed: %v", err)
 	}
-	
+
 	// Set up routes
-	router := setupRoutes()
-	
+	handler := setupRoutes()
+
+	// Periodically refresh the dogs_by_status gauge
+	go sampleDogsByStatusPeriodically()
+
 	// Get port from environment variable or use default
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "5100" // Use the same port as the original Flask server
 	}
-	
+
 	// Start the server
 	serverAddr := fmt.Sprintf(":%s", port)
 	log.Printf("Server starting on http://localhost%s", serverAddr)
-	
-	if err := http.ListenAndServe(serverAddr, router); err != nil {
+
+	if err := http.ListenAndServe(serverAddr, handler); err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
-}
\ No newline at end of file
+}
+
+// sampleDogsByStatusPeriodically keeps the dogs_by_status gauge fresh for as
+// long as the server runs
+func sampleDogsByStatusPeriodically() {
+	ticker := time.NewTicker(dogsByStatusSampleInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := models.SampleDogsByStatus(); err != nil {
+			log.Printf("error sampling dogs_by_status: %v", err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Package metrics defines the Prometheus collectors exposed by the API and
+// a handful of helpers for recording observations against them.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// HTTPRequestsTotal counts every request the API has handled
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests processed",
+	}, []string{"method", "path", "status"})
+
+	// HTTPRequestDuration tracks how long requests take to handle
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+
+	// DBQueryDuration tracks how long individual named database queries take
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency in seconds",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"query"})
+
+	// DogsByStatus is periodically sampled from the database to report how
+	// many dogs are currently in each adoption status
+	DogsByStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dogs_by_status",
+		Help: "Current number of dogs in the shelter by adoption status",
+	}, []string{"status"})
+)
+
+// Handler serves the Prometheus text exposition format for /metrics
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveDBQuery records the duration of a named query, measured from start
+func ObserveDBQuery(query string, start time.Time) {
+	DBQueryDuration.WithLabelValues(query).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migration files shipped with the
+// binary so the server doesn't depend on a migrations directory existing
+// on disk at runtime.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Adopter represents a person who has reserved or adopted a dog
+type Adopter struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// AdoptionEvent is an audit log entry recording a single status transition
+type AdoptionEvent struct {
+	ID        int            `json:"id"`
+	DogID     int            `json:"dog_id"`
+	AdopterID *int           `json:"adopter_id,omitempty"`
+	FromState AdoptionStatus `json:"from_state"`
+	ToState   AdoptionStatus `json:"to_state"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// allowedTransitions enumerates the valid AdoptionStatus state machine edges
+var allowedTransitions = map[AdoptionStatus][]AdoptionStatus{
+	Available: {Pending},
+	Pending:   {Adopted, Available},
+	Adopted:   {Available},
+}
+
+// ErrInvalidTransition is returned when a requested status change does not
+// match the dog's current status or is not a legal state machine edge
+var ErrInvalidTransition = fmt.Errorf("invalid adoption status transition")
+
+// ErrDogNotFound is returned when UpdateDogStatus targets a non-existent dog
+var ErrDogNotFound = fmt.Errorf("dog not found")
+
+// ErrConcurrentUpdate is returned when UpdateDogStatus loses a race with
+// another request transitioning the same dog and SQLite can't grant the
+// write lock; callers should treat it like any other conflict and retry
+var ErrConcurrentUpdate = fmt.Errorf("dog status changed concurrently, please retry")
+
+func isAllowedTransition(from, to AdoptionStatus) bool {
+	for _, candidate := range allowedTransitions[from] {
+		if candidate == to {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateDogStatus transitions a dog from one adoption status to another,
+// enforcing the state machine defined by allowedTransitions. The read and
+// write happen inside a single transaction opened with SQLite's BEGIN
+// IMMEDIATE (via the _txlock=immediate DSN parameter set in OpenDB) so two
+// concurrent requests can't both win the same transition: the loser waits
+// for the write lock, then finds the status already changed and gets
+// ErrInvalidTransition, or gets ErrConcurrentUpdate if SQLite reports the
+// database as locked.
+// adopter is required when transitioning into Pending or Adopted, and is
+// ignored otherwise.
+func UpdateDogStatus(id int, from, to AdoptionStatus, adopter *Adopter) (*DogResponse, error) {
+	if !isAllowedTransition(from, to) {
+		return nil, ErrInvalidTransition
+	}
+
+	tx, err := DB.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		if isDatabaseLockedErr(err) {
+			return nil, ErrConcurrentUpdate
+		}
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var currentStatus string
+	var adopterID sql.NullInt64
+	err = tx.QueryRow(`SELECT status, adopter_id FROM dogs WHERE id = ?`, id).Scan(&currentStatus, &adopterID)
+	if err == sql.ErrNoRows {
+		return nil, ErrDogNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading dog status: %w", err)
+	}
+
+	if AdoptionStatus(currentStatus) != from {
+		return nil, ErrInvalidTransition
+	}
+
+	var newAdopterID sql.NullInt64
+	var adoptionDate sql.NullTime
+
+	switch to {
+	case Pending, Adopted:
+		if adopter == nil {
+			return nil, fmt.Errorf("%w: adopter is required", ErrInvalidTransition)
+		}
+		resolvedID, err := upsertAdopter(tx, adopter)
+		if err != nil {
+			return nil, err
+		}
+		newAdopterID = sql.NullInt64{Int64: int64(resolvedID), Valid: true}
+		if to == Adopted {
+			adoptionDate = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+		}
+	case Available:
+		newAdopterID = sql.NullInt64{}
+		adoptionDate = sql.NullTime{}
+	}
+
+	_, err = tx.Exec(
+		`UPDATE dogs SET status = ?, adopter_id = ?, adoption_date = ? WHERE id = ?`,
+		string(to), newAdopterID, adoptionDate, id,
+	)
+	if err != nil {
+		if isDatabaseLockedErr(err) {
+			return nil, ErrConcurrentUpdate
+		}
+		return nil, fmt.Errorf("error updating dog status: %w", err)
+	}
+
+	var eventAdopterID *int
+	if newAdopterID.Valid {
+		v := int(newAdopterID.Int64)
+		eventAdopterID = &v
+	}
+	_, err = tx.Exec(
+		`INSERT INTO adoption_events (dog_id, adopter_id, from_state, to_state, created_at) VALUES (?, ?, ?, ?, ?)`,
+		id, eventAdopterID, string(from), string(to), time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error recording adoption event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		if isDatabaseLockedErr(err) {
+			return nil, ErrConcurrentUpdate
+		}
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return GetDogByID(id)
+}
+
+// isDatabaseLockedErr reports whether err is SQLite's "database is locked"
+// (SQLITE_BUSY), which BEGIN IMMEDIATE surfaces when another transaction
+// holds the write lock for longer than the driver's busy timeout
+func isDatabaseLockedErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "database is locked")
+}
+
+// upsertAdopter finds an existing adopter by email or inserts a new one,
+// returning its ID. It must be called within an open transaction.
+func upsertAdopter(tx *sql.Tx, adopter *Adopter) (int, error) {
+	var id int
+	err := tx.QueryRow(`SELECT id FROM adopters WHERE email = ?`, adopter.Email).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("error looking up adopter: %w", err)
+	}
+
+	res, err := tx.Exec(
+		`INSERT INTO adopters (name, email, phone) VALUES (?, ?, ?)`,
+		adopter.Name, adopter.Email, adopter.Phone,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error inserting adopter: %w", err)
+	}
+
+	newID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("error reading new adopter id: %w", err)
+	}
+
+	return int(newID), nil
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// setupTestDB points the package-level DB at a fresh in-memory database with
+// all migrations applied, for the lifetime of the test
+func setupTestDB(t *testing.T) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file::memory:?_txlock=immediate")
+	if err != nil {
+		t.Fatalf("error opening test database: %v", err)
+	}
+	db.SetMaxOpenConns(1) // :memory: databases are per-connection
+	t.Cleanup(func() { db.Close() })
+
+	if err := Migrate(context.Background(), db); err != nil {
+		t.Fatalf("error migrating test database: %v", err)
+	}
+
+	DB = db
+}
+
+// seedDog inserts a breed and an Available dog, returning the dog's ID
+func seedDog(t *testing.T) int {
+	t.Helper()
+
+	breed, err := CreateBreed(&Breed{Name: "Labrador Retriever", Description: "Friendly and active"})
+	if err != nil {
+		t.Fatalf("CreateBreed() error = %v", err)
+	}
+
+	dog, err := CreateDog(&Dog{Name: "Rex", BreedID: breed.ID, Age: 2, Gender: "Male"})
+	if err != nil {
+		t.Fatalf("CreateDog() error = %v", err)
+	}
+
+	return dog.ID
+}
+
+func countAdoptionEvents(t *testing.T, dogID int) int {
+	t.Helper()
+
+	var count int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM adoption_events WHERE dog_id = ?`, dogID).Scan(&count); err != nil {
+		t.Fatalf("error counting adoption_events: %v", err)
+	}
+	return count
+}
+
+func TestUpdateDogStatus_FullLifecycle(t *testing.T) {
+	setupTestDB(t)
+	dogID := seedDog(t)
+	adopter := &Adopter{Name: "Jane Doe", Email: "jane@example.com"}
+
+	if _, err := UpdateDogStatus(dogID, Available, Pending, adopter); err != nil {
+		t.Fatalf("Available->Pending: unexpected error %v", err)
+	}
+
+	if _, err := UpdateDogStatus(dogID, Pending, Adopted, adopter); err != nil {
+		t.Fatalf("Pending->Adopted: unexpected error %v", err)
+	}
+
+	dog, err := GetDogByID(dogID)
+	if err != nil {
+		t.Fatalf("GetDogByID() error = %v", err)
+	}
+	if dog.Status != string(Adopted) {
+		t.Errorf("status = %q, want %q", dog.Status, Adopted)
+	}
+
+	if got := countAdoptionEvents(t, dogID); got != 2 {
+		t.Errorf("adoption_events rows = %d, want 2", got)
+	}
+
+	if _, err := UpdateDogStatus(dogID, Adopted, Available, nil); err != nil {
+		t.Fatalf("Adopted->Available: unexpected error %v", err)
+	}
+
+	dog, err = GetDogByID(dogID)
+	if err != nil {
+		t.Fatalf("GetDogByID() error = %v", err)
+	}
+	if dog.Status != string(Available) {
+		t.Errorf("status = %q, want %q", dog.Status, Available)
+	}
+}
+
+func TestUpdateDogStatus_RejectsSkippingPending(t *testing.T) {
+	setupTestDB(t)
+	dogID := seedDog(t)
+	adopter := &Adopter{Name: "Jane Doe", Email: "jane@example.com"}
+
+	_, err := UpdateDogStatus(dogID, Available, Adopted, adopter)
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("error = %v, want ErrInvalidTransition", err)
+	}
+}
+
+func TestUpdateDogStatus_RejectsStaleFromState(t *testing.T) {
+	setupTestDB(t)
+	dogID := seedDog(t)
+	adopter := &Adopter{Name: "Jane Doe", Email: "jane@example.com"}
+
+	if _, err := UpdateDogStatus(dogID, Available, Pending, adopter); err != nil {
+		t.Fatalf("setup transition: unexpected error %v", err)
+	}
+
+	// The dog already moved to Pending; a second caller still assuming
+	// Available must lose, not silently re-apply the transition.
+	_, err := UpdateDogStatus(dogID, Available, Pending, adopter)
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("error = %v, want ErrInvalidTransition", err)
+	}
+}
+
+func TestUpdateDogStatus_RequiresAdopterIntoPending(t *testing.T) {
+	setupTestDB(t)
+	dogID := seedDog(t)
+
+	_, err := UpdateDogStatus(dogID, Available, Pending, nil)
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("error = %v, want ErrInvalidTransition", err)
+	}
+}
+
+func TestUpdateDogStatus_DogNotFound(t *testing.T) {
+	setupTestDB(t)
+
+	_, err := UpdateDogStatus(99999, Available, Pending, &Adopter{Name: "Jane Doe", Email: "jane@example.com"})
+	if !errors.Is(err, ErrDogNotFound) {
+		t.Errorf("error = %v, want ErrDogNotFound", err)
+	}
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ErrBreedNotFound is returned by DeleteBreed when no breed matches the given
+// ID, and by CreateDog, UpdateDog, and PatchDog when a dog's breed_id doesn't
+// reference an existing breed
+var ErrBreedNotFound = fmt.Errorf("breed not found")
+
+// ErrBreedInUse is returned by DeleteBreed when one or more dogs still
+// reference the breed
+var ErrBreedInUse = fmt.Errorf("breed is referenced by existing dogs")
+
+// BreedPatch carries the fields a PATCH request wants to change. A nil field
+// means "leave unchanged".
+type BreedPatch struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// CreateBreed inserts a new breed
+func CreateBreed(b *Breed) (*BreedResponse, error) {
+	res, err := DB.Exec(`INSERT INTO breeds (name, description) VALUES (?, ?)`, b.Name, b.Description)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting breed: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("error reading new breed id: %w", err)
+	}
+
+	return getBreedByID(int(id))
+}
+
+// UpdateBreed replaces a breed's name and description, returning nil if no
+// breed with that ID exists
+func UpdateBreed(id int, b *Breed) (*BreedResponse, error) {
+	res, err := DB.Exec(`UPDATE breeds SET name = ?, description = ? WHERE id = ?`, b.Name, b.Description, id)
+	if err != nil {
+		return nil, fmt.Errorf("error updating breed: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("error reading rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+
+	return getBreedByID(id)
+}
+
+// PatchBreed applies a partial update, only touching fields present in
+// patch, returning nil if no breed with that ID exists
+func PatchBreed(id int, patch BreedPatch) (*BreedResponse, error) {
+	sets := []string{}
+	args := []interface{}{}
+
+	if patch.Name != nil {
+		sets = append(sets, "name = ?")
+		args = append(args, *patch.Name)
+	}
+	if patch.Description != nil {
+		sets = append(sets, "description = ?")
+		args = append(args, *patch.Description)
+	}
+
+	if len(sets) == 0 {
+		if exists, err := breedExists(id); err != nil || !exists {
+			return nil, err
+		}
+		return getBreedByID(id)
+	}
+
+	query := "UPDATE breeds SET " + joinSets(sets) + " WHERE id = ?"
+	args = append(args, id)
+
+	res, err := DB.Exec(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error patching breed: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("error reading rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+
+	return getBreedByID(id)
+}
+
+// DeleteBreed removes a breed by ID, returning ErrBreedNotFound if none
+// matched and ErrBreedInUse if dogs still reference it
+func DeleteBreed(id int) error {
+	var dogCount int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM dogs WHERE breed_id = ?`, id).Scan(&dogCount); err != nil {
+		return fmt.Errorf("error checking dogs referencing breed: %w", err)
+	}
+	if dogCount > 0 {
+		return ErrBreedInUse
+	}
+
+	res, err := DB.Exec(`DELETE FROM breeds WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting breed: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrBreedNotFound
+	}
+
+	return nil
+}
+
+func getBreedByID(id int) (*BreedResponse, error) {
+	var breed BreedResponse
+	err := DB.QueryRow(`SELECT id, name FROM breeds WHERE id = ?`, id).Scan(&breed.ID, &breed.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error reading breed: %w", err)
+	}
+	return &breed, nil
+}
+
+// GetBreedRaw fetches a breed's full row by ID, for callers (like patch
+// validation) that need the stored description as well as the name. Returns
+// nil if no breed matches.
+func GetBreedRaw(id int) (*Breed, error) {
+	var b Breed
+	var description sql.NullString
+
+	err := DB.QueryRow(`SELECT id, name, description FROM breeds WHERE id = ?`, id).Scan(&b.ID, &b.Name, &description)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading breed: %w", err)
+	}
+
+	b.Description = description.String
+	return &b, nil
+}
@@ -1,99 +1,142 @@
 package models
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/go_server/metrics"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 // DB represents the database connection
 var DB *sql.DB
 
-// InitDB initializes the database connection
+// defaultDBPath is used when DB_PATH isn't set
+const defaultDBPath = "dogshelter.db"
+
+// InitDB opens the SQLite database at DB_PATH (or defaultDBPath) and applies
+// any pending migrations
 func InitDB() error {
-	// Get the current directory
-	dir, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("error getting current directory: %w", err)
+	if err := OpenDB(); err != nil {
+		return err
 	}
 
-	// Use the original SQLite database file path
-	dbPath := filepath.Join(dir, "..", "server", "dogshelter.db")
-	
-	// Open the SQLite database
-	db, err := sql.Open("sqlite3", dbPath)
+	if err := Migrate(context.Background(), DB); err != nil {
+		return fmt.Errorf("error applying migrations: %w", err)
+	}
+
+	return nil
+}
+
+// OpenDB opens the SQLite database at DB_PATH (or defaultDBPath) and sets
+// the global DB handle, without applying migrations. Callers that need
+// fine-grained control over migrations (e.g. cmd/migrate) should use this
+// directly instead of InitDB.
+func OpenDB() error {
+	dbPath := dbPathFromEnv()
+
+	db, err := sql.Open("sqlite3", sqliteDSN(dbPath))
 	if err != nil {
 		return fmt.Errorf("error opening database: %w", err)
 	}
 
-	// Set connection parameters
 	db.SetMaxOpenConns(10)
 	db.SetMaxIdleConns(5)
 	db.SetConnMaxLifetime(time.Hour)
 
-	// Verify the connection
 	if err := db.Ping(); err != nil {
 		return fmt.Errorf("error connecting to database: %w", err)
 	}
 
-	// Set the global DB variable
 	DB = db
 	log.Printf("Connected to database: %s", dbPath)
 	return nil
 }
 
-// GetDogs retrieves all dogs from the database, optionally filtered
-func GetDogs(breedID *int, availableOnly bool) ([]DogResponse, error) {
-	query := `
-		SELECT d.id, d.name, b.name as breed
-		FROM dogs d
-		JOIN breeds b ON d.breed_id = b.id
-		WHERE 1=1
-	`
-	
-	// Add filter conditions
+// sqliteDSN appends _txlock=immediate to path so go-sqlite3 issues "BEGIN
+// IMMEDIATE" for every transaction instead of its default deferred lock,
+// giving functions like UpdateDogStatus a real write lock up front rather
+// than relying on SQLITE_BUSY at commit time to serialize writers
+func sqliteDSN(path string) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + "_txlock=immediate"
+}
+
+// dbPathFromEnv reads the configured database path from DB_PATH, falling
+// back to defaultDBPath if unset
+func dbPathFromEnv() string {
+	if path := os.Getenv("DB_PATH"); path != "" {
+		return path
+	}
+	return defaultDBPath
+}
+
+// GetDogs retrieves a page of dogs from the database, optionally filtered,
+// along with the total count of dogs matching the filter (ignoring limit and
+// offset) so callers can build pagination headers.
+func GetDogs(breedID *int, availableOnly bool, limit, offset int) ([]DogResponse, int, error) {
+	defer metrics.ObserveDBQuery("get_dogs", time.Now())
+
+	where := " WHERE 1=1"
 	args := []interface{}{}
+
 	if breedID != nil {
-		query += " AND d.breed_id = ?"
+		where += " AND d.breed_id = ?"
 		args = append(args, *breedID)
 	}
-	
+
 	if availableOnly {
-		query += " AND d.status = ?"
+		where += " AND d.status = ?"
 		args = append(args, string(Available))
 	}
 
-	// Execute the query
-	rows, err := DB.Query(query, args...)
+	var total int
+	countQuery := "SELECT COUNT(*) FROM dogs d" + where
+	if err := DB.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting dogs: %w", err)
+	}
+
+	query := `
+		SELECT d.id, d.name, b.name as breed
+		FROM dogs d
+		JOIN breeds b ON d.breed_id = b.id
+	` + where + " LIMIT ? OFFSET ?"
+	pagedArgs := append(append([]interface{}{}, args...), limit, offset)
+
+	rows, err := DB.Query(query, pagedArgs...)
 	if err != nil {
-		return nil, fmt.Errorf("error querying dogs: %w", err)
+		return nil, 0, fmt.Errorf("error querying dogs: %w", err)
 	}
 	defer rows.Close()
 
-	// Process results
 	var dogs []DogResponse
 	for rows.Next() {
 		var dog DogResponse
 		if err := rows.Scan(&dog.ID, &dog.Name, &dog.Breed); err != nil {
-			return nil, fmt.Errorf("error scanning dog row: %w", err)
+			return nil, 0, fmt.Errorf("error scanning dog row: %w", err)
 		}
 		dogs = append(dogs, dog)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating dog rows: %w", err)
+		return nil, 0, fmt.Errorf("error iterating dog rows: %w", err)
 	}
 
-	return dogs, nil
+	return dogs, total, nil
 }
 
 // GetDogByID retrieves a dog by ID
 func GetDogByID(id int) (*DogResponse, error) {
+	defer metrics.ObserveDBQuery("get_dog_by_id", time.Now())
+
 	query := `
 		SELECT d.id, d.name, b.name as breed, d.age, d.description, d.gender, d.status
 		FROM dogs d
@@ -130,6 +173,8 @@ func GetDogByID(id int) (*DogResponse, error) {
 
 // GetBreeds retrieves all breeds from the database
 func GetBreeds() ([]BreedResponse, error) {
+	defer metrics.ObserveDBQuery("get_breeds", time.Now())
+
 	query := "SELECT id, name FROM breeds"
 	
 	rows, err := DB.Query(query)
@@ -150,6 +195,36 @@ func GetBreeds() ([]BreedResponse, error) {
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating breed rows: %w", err)
 	}
-	
+
 	return breeds, nil
+}
+
+// SampleDogsByStatus queries the current count of dogs in each adoption
+// status and publishes it to the dogs_by_status gauge
+func SampleDogsByStatus() error {
+	rows, err := DB.Query(`SELECT status, COUNT(*) FROM dogs GROUP BY status`)
+	if err != nil {
+		return fmt.Errorf("error sampling dogs by status: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return fmt.Errorf("error scanning status count: %w", err)
+		}
+		counts[status] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating status counts: %w", err)
+	}
+
+	for _, status := range []AdoptionStatus{Available, Pending, Adopted} {
+		metrics.DogsByStatus.WithLabelValues(string(status)).Set(float64(counts[string(status)]))
+	}
+
+	return nil
 }
\ No newline at end of file
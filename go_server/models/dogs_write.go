@@ -0,0 +1,197 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DogPatch carries the fields a PATCH request wants to change. A nil field
+// means "leave unchanged".
+type DogPatch struct {
+	Name        *string `json:"name"`
+	BreedID     *int    `json:"breed_id"`
+	Age         *int    `json:"age"`
+	Gender      *string `json:"gender"`
+	Description *string `json:"description"`
+}
+
+// CreateDog inserts a new dog, always starting it as Available (any
+// client-supplied status is ignored, since moving into Pending/Adopted must
+// go through UpdateDogStatus to get an adopter and an audit trail) with its
+// intake date set to now. It returns ErrBreedNotFound if d.BreedID doesn't
+// reference an existing breed.
+func CreateDog(d *Dog) (*DogResponse, error) {
+	d.Status = Available
+
+	exists, err := breedExists(d.BreedID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrBreedNotFound
+	}
+
+	res, err := DB.Exec(
+		`INSERT INTO dogs (name, breed_id, age, gender, description, status, intake_date) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		d.Name, d.BreedID, d.Age, d.Gender, d.Description, string(d.Status), time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting dog: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("error reading new dog id: %w", err)
+	}
+
+	return GetDogByID(int(id))
+}
+
+// UpdateDog replaces a dog's mutable fields (name, breed, age, gender,
+// description), returning nil if no dog with that ID exists. It returns
+// ErrBreedNotFound if d.BreedID doesn't reference an existing breed.
+func UpdateDog(id int, d *Dog) (*DogResponse, error) {
+	exists, err := breedExists(d.BreedID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrBreedNotFound
+	}
+
+	res, err := DB.Exec(
+		`UPDATE dogs SET name = ?, breed_id = ?, age = ?, gender = ?, description = ? WHERE id = ?`,
+		d.Name, d.BreedID, d.Age, d.Gender, d.Description, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error updating dog: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("error reading rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+
+	return GetDogByID(id)
+}
+
+// PatchDog applies a partial update, only touching fields present in patch,
+// returning nil if no dog with that ID exists. It returns ErrBreedNotFound
+// if patch.BreedID is set but doesn't reference an existing breed.
+func PatchDog(id int, patch DogPatch) (*DogResponse, error) {
+	if patch.BreedID != nil {
+		exists, err := breedExists(*patch.BreedID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrBreedNotFound
+		}
+	}
+
+	sets := []string{}
+	args := []interface{}{}
+
+	if patch.Name != nil {
+		sets = append(sets, "name = ?")
+		args = append(args, *patch.Name)
+	}
+	if patch.BreedID != nil {
+		sets = append(sets, "breed_id = ?")
+		args = append(args, *patch.BreedID)
+	}
+	if patch.Age != nil {
+		sets = append(sets, "age = ?")
+		args = append(args, *patch.Age)
+	}
+	if patch.Gender != nil {
+		sets = append(sets, "gender = ?")
+		args = append(args, *patch.Gender)
+	}
+	if patch.Description != nil {
+		sets = append(sets, "description = ?")
+		args = append(args, *patch.Description)
+	}
+
+	if len(sets) == 0 {
+		return GetDogByID(id)
+	}
+
+	query := "UPDATE dogs SET " + joinSets(sets) + " WHERE id = ?"
+	args = append(args, id)
+
+	res, err := DB.Exec(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error patching dog: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("error reading rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+
+	return GetDogByID(id)
+}
+
+// DeleteDog removes a dog by ID, returning ErrDogNotFound if none matched
+func DeleteDog(id int) error {
+	res, err := DB.Exec(`DELETE FROM dogs WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting dog: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrDogNotFound
+	}
+
+	return nil
+}
+
+// GetDogRaw fetches a dog's unjoined row by ID, for callers (like patch
+// validation) that need the stored field values rather than the display
+// form GetDogByID returns. Returns nil if no dog matches.
+func GetDogRaw(id int) (*Dog, error) {
+	var d Dog
+	var status string
+
+	err := DB.QueryRow(
+		`SELECT id, name, breed_id, age, gender, description, status FROM dogs WHERE id = ?`, id,
+	).Scan(&d.ID, &d.Name, &d.BreedID, &d.Age, &d.Gender, &d.Description, &status)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading dog: %w", err)
+	}
+
+	d.Status = AdoptionStatus(status)
+	return &d, nil
+}
+
+// breedExists reports whether a breed with the given ID exists
+func breedExists(id int) (bool, error) {
+	var exists bool
+	if err := DB.QueryRow(`SELECT EXISTS(SELECT 1 FROM breeds WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return false, fmt.Errorf("error checking breed existence: %w", err)
+	}
+	return exists, nil
+}
+
+func joinSets(sets []string) string {
+	out := sets[0]
+	for _, s := range sets[1:] {
+		out += ", " + s
+	}
+	return out
+}
@@ -0,0 +1,269 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go_server/migrations"
+)
+
+// migrationFile is one parsed entry from the embedded migrations directory
+type migrationFile struct {
+	version int
+	name    string
+	upSQL   string
+	downSQL string
+}
+
+// MigrationStatus describes whether a single migration has been applied
+type MigrationStatus struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+const createMigrationsTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at DATETIME NOT NULL
+	)
+`
+
+// Migrate applies every pending migration, in version order, each inside
+// its own transaction
+func Migrate(ctx context.Context, db *sql.DB) error {
+	files, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return fmt.Errorf("error creating schema_migrations table: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if applied[f.version] {
+			continue
+		}
+
+		if err := runInTx(ctx, db, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, f.upSQL); err != nil {
+				return fmt.Errorf("error applying migration %04d_%s: %w", f.version, f.name, err)
+			}
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+				f.version, f.name, time.Now().UTC(),
+			)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateDown reverts the most recently applied migration
+func MigrateDown(ctx context.Context, db *sql.DB) error {
+	files, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	var target *migrationFile
+	for i := len(files) - 1; i >= 0; i-- {
+		if applied[files[i].version] {
+			target = &files[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil
+	}
+
+	return runInTx(ctx, db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, target.downSQL); err != nil {
+			return fmt.Errorf("error reverting migration %04d_%s: %w", target.version, target.name, err)
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, target.version)
+		return err
+	})
+}
+
+// MigrationStatuses reports whether each known migration has been applied
+func MigrationStatuses(ctx context.Context, db *sql.DB) ([]MigrationStatus, error) {
+	files, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, fmt.Errorf("error creating schema_migrations table: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(files))
+	for _, f := range files {
+		statuses = append(statuses, MigrationStatus{
+			Version: f.version,
+			Name:    f.name,
+			Applied: applied[f.version],
+		})
+	}
+
+	return statuses, nil
+}
+
+// Seed populates the database with a small set of sample breeds and dogs,
+// useful for local development against an otherwise empty database
+func Seed(ctx context.Context, db *sql.DB) error {
+	return runInTx(ctx, db, func(tx *sql.Tx) error {
+		breeds := []Breed{
+			{Name: "Labrador Retriever", Description: "Friendly, active, and outgoing"},
+			{Name: "Beagle", Description: "Curious, merry, and friendly"},
+			{Name: "Poodle", Description: "Proud, clever, and active"},
+		}
+
+		breedIDs := make(map[string]int64, len(breeds))
+		for _, b := range breeds {
+			res, err := tx.ExecContext(ctx, `INSERT INTO breeds (name, description) VALUES (?, ?)`, b.Name, b.Description)
+			if err != nil {
+				return fmt.Errorf("error seeding breed %q: %w", b.Name, err)
+			}
+			id, err := res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			breedIDs[b.Name] = id
+		}
+
+		dogs := []struct {
+			name, breed, gender, description string
+			age                              int
+		}{
+			{"Buddy", "Labrador Retriever", "Male", "A playful pup who loves to fetch", 2},
+			{"Daisy", "Beagle", "Female", "A gentle soul who enjoys long walks", 4},
+			{"Max", "Poodle", "Male", "A sharp, well-groomed companion", 3},
+		}
+
+		now := time.Now().UTC()
+		for _, d := range dogs {
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO dogs (name, breed_id, age, gender, description, status, intake_date) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				d.name, breedIDs[d.breed], d.age, d.gender, d.description, string(Available), now,
+			)
+			if err != nil {
+				return fmt.Errorf("error seeding dog %q: %w", d.name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func appliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("error reading applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("error scanning migration version: %w", err)
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func runInTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// loadMigrations reads and pairs up the embedded .up.sql/.down.sql files,
+// sorted by version ascending
+func loadMigrations() ([]migrationFile, error) {
+	entries, err := migrations.FS.ReadDir(".")
+	if err != nil {
+		return nil, fmt.Errorf("error reading embedded migrations: %w", err)
+	}
+
+	byVersion := map[int]*migrationFile{}
+	for _, entry := range entries {
+		filename := entry.Name()
+		isUp := strings.HasSuffix(filename, ".up.sql")
+		isDown := strings.HasSuffix(filename, ".down.sql")
+		if !isUp && !isDown {
+			continue
+		}
+
+		base := strings.TrimSuffix(strings.TrimSuffix(filename, ".up.sql"), ".down.sql")
+		parts := strings.SplitN(base, "_", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("migration filename %q doesn't match NNNN_name pattern", filename)
+		}
+
+		version, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("migration filename %q has a non-numeric version: %w", filename, err)
+		}
+
+		content, err := migrations.FS.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %q: %w", filename, err)
+		}
+
+		f, ok := byVersion[version]
+		if !ok {
+			f = &migrationFile{version: version, name: parts[1]}
+			byVersion[version] = f
+		}
+		if isUp {
+			f.upSQL = string(content)
+		} else {
+			f.downSQL = string(content)
+		}
+	}
+
+	files := make([]migrationFile, 0, len(byVersion))
+	for _, f := range byVersion {
+		files = append(files, *f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].version < files[j].version })
+
+	return files, nil
+}
@@ -50,40 +50,61 @@ type BreedResponse struct {
 	Name string `json:"name"`
 }
 
-// ErrorResponse is used for error messages
+// ErrorResponse is used for error messages. Code is a short machine-readable
+// identifier for the error (e.g. "validation_error"); Fields carries
+// field-level validation errors keyed by JSON field name, when applicable.
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error  string            `json:"error"`
+	Code   string            `json:"code,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 // Validate validates a dog's data
 func (d *Dog) Validate() bool {
-	if d.Name == "" || len(d.Name) < 2 {
-		return false
+	return len(d.ValidationErrors()) == 0
+}
+
+// ValidationErrors returns field-level validation errors for a dog, keyed by
+// JSON field name. An empty map means the dog is valid.
+func (d *Dog) ValidationErrors() map[string]string {
+	errs := map[string]string{}
+
+	if d.Name == "" {
+		errs["name"] = "name is required"
+	} else if len(d.Name) < 2 {
+		errs["name"] = "name must be at least 2 characters"
 	}
 
-	// Gender validation
 	if d.Gender != "Male" && d.Gender != "Female" && d.Gender != "Unknown" {
-		return false
+		errs["gender"] = "gender must be one of Male, Female, Unknown"
 	}
 
-	// Description validation - if provided, must be at least 10 chars
 	if d.Description != "" && len(d.Description) < 10 {
-		return false
+		errs["description"] = "description must be at least 10 characters"
 	}
 
-	return true
+	return errs
 }
 
 // Validate validates a breed's data
 func (b *Breed) Validate() bool {
-	if b.Name == "" || len(b.Name) < 2 {
-		return false
+	return len(b.ValidationErrors()) == 0
+}
+
+// ValidationErrors returns field-level validation errors for a breed, keyed
+// by JSON field name. An empty map means the breed is valid.
+func (b *Breed) ValidationErrors() map[string]string {
+	errs := map[string]string{}
+
+	if b.Name == "" {
+		errs["name"] = "name is required"
+	} else if len(b.Name) < 2 {
+		errs["name"] = "name must be at least 2 characters"
 	}
 
-	// Description validation - if provided, must be at least 10 chars
 	if b.Description != "" && len(b.Description) < 10 {
-		return false
+		errs["description"] = "description must be at least 10 characters"
 	}
 
-	return true
+	return errs
 }
\ No newline at end of file
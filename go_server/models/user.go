@@ -0,0 +1,149 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt cost parameters. N is the CPU/memory cost factor (must be a power
+// of two); r and p are the block size and parallelization factors. These
+// match the scrypt paper's interactive-use recommendation.
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+)
+
+// UserRole is one of the fixed roles a user account can hold
+type UserRole string
+
+const (
+	RoleAdmin  UserRole = "admin"
+	RoleStaff  UserRole = "staff"
+	RolePublic UserRole = "public"
+)
+
+// User represents an account that can authenticate against the API
+type User struct {
+	ID       int      `json:"id"`
+	Username string   `json:"username"`
+	Email    string   `json:"email"`
+	Role     UserRole `json:"role"`
+}
+
+// ErrUserExists is returned by CreateUser when the username or email is taken
+var ErrUserExists = fmt.Errorf("user already exists")
+
+// ErrInvalidCredentials is returned by Authenticate when the username or
+// password doesn't match a stored account
+var ErrInvalidCredentials = fmt.Errorf("invalid credentials")
+
+// CreateUser inserts a new user with the given password, which is salted and
+// hashed before storage. role defaults to RolePublic.
+func CreateUser(username, email, password string, role UserRole) (*User, error) {
+	if role == "" {
+		role = RolePublic
+	}
+
+	salt, hash, err := hashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	res, err := DB.Exec(
+		`INSERT INTO users (username, email, password_hash, password_salt, role) VALUES (?, ?, ?, ?, ?)`,
+		username, email, hash, salt, string(role),
+	)
+	if err != nil {
+		if isUniqueConstraintErr(err) {
+			return nil, ErrUserExists
+		}
+		return nil, fmt.Errorf("error inserting user: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("error reading new user id: %w", err)
+	}
+
+	return &User{ID: int(id), Username: username, Email: email, Role: role}, nil
+}
+
+// Authenticate verifies a username/password pair and returns the matching user
+func Authenticate(username, password string) (*User, error) {
+	row := DB.QueryRow(
+		`SELECT id, username, email, password_hash, password_salt, role FROM users WHERE username = ?`,
+		username,
+	)
+
+	var (
+		user User
+		hash string
+		salt string
+		role string
+	)
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &hash, &salt, &role)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading user: %w", err)
+	}
+
+	if !verifyPassword(password, salt, hash) {
+		return nil, ErrInvalidCredentials
+	}
+
+	user.Role = UserRole(role)
+	return &user, nil
+}
+
+func hashPassword(password string) (salt, hash string, err error) {
+	saltBytes := make([]byte, 16)
+	if _, err := rand.Read(saltBytes); err != nil {
+		return "", "", err
+	}
+	salt = hex.EncodeToString(saltBytes)
+
+	hash, err = derivePasswordHash(password, salt)
+	if err != nil {
+		return "", "", err
+	}
+	return salt, hash, nil
+}
+
+func verifyPassword(password, salt, hash string) bool {
+	derived, err := derivePasswordHash(password, salt)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(derived), []byte(hash)) == 1
+}
+
+// derivePasswordHash runs scrypt, a deliberately slow and memory-hard KDF,
+// over password+salt so stored hashes can't be brute-forced at GPU/ASIC
+// speed the way a single SHA-256 round could be
+func derivePasswordHash(password, salt string) (string, error) {
+	saltBytes, err := hex.DecodeString(salt)
+	if err != nil {
+		return "", fmt.Errorf("error decoding salt: %w", err)
+	}
+
+	sum, err := scrypt.Key([]byte(password), saltBytes, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return "", fmt.Errorf("error deriving password hash: %w", err)
+	}
+
+	return hex.EncodeToString(sum), nil
+}
+
+func isUniqueConstraintErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
@@ -0,0 +1,179 @@
+// Package router provides a small HTTP router supporting named path
+// parameters and a composable middleware chain, replacing the manual path
+// slicing that used to live in main.go.
+package router
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Middleware wraps an http.Handler to add cross-cutting behavior
+type Middleware func(http.Handler) http.Handler
+
+type varsKey struct{}
+
+type patternKey struct{}
+
+// route is a single registered method+pattern pair
+type route struct {
+	method   string
+	pattern  string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+// Router matches requests against registered routes and applies a shared
+// middleware chain around whichever handler matches
+type Router struct {
+	routes     []route
+	middleware []Middleware
+	notFound   http.HandlerFunc
+}
+
+// New creates an empty Router
+func New() *Router {
+	return &Router{
+		notFound: http.NotFound,
+	}
+}
+
+// Use appends middleware to the chain applied to every request. Middleware
+// runs in the order it was added, outermost first.
+func (rt *Router) Use(mw ...Middleware) {
+	rt.middleware = append(rt.middleware, mw...)
+}
+
+// Handle registers a handler for an exact method and path pattern, e.g.
+// Handle(http.MethodGet, "/api/dogs/{id}", handler). Segments wrapped in
+// curly braces are captured as path parameters.
+func (rt *Router) Handle(method, pattern string, handler http.HandlerFunc) {
+	rt.routes = append(rt.routes, route{
+		method:   method,
+		pattern:  pattern,
+		segments: splitPath(pattern),
+		handler:  handler,
+	})
+}
+
+// Get registers a GET route
+func (rt *Router) Get(pattern string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodGet, pattern, handler)
+}
+
+// Post registers a POST route
+func (rt *Router) Post(pattern string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodPost, pattern, handler)
+}
+
+// Put registers a PUT route
+func (rt *Router) Put(pattern string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodPut, pattern, handler)
+}
+
+// Patch registers a PATCH route
+func (rt *Router) Patch(pattern string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodPatch, pattern, handler)
+}
+
+// Delete registers a DELETE route
+func (rt *Router) Delete(pattern string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodDelete, pattern, handler)
+}
+
+// ServeHTTP implements http.Handler, matching the request against registered
+// routes and running the middleware chain around whichever one matches
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqSegments := splitPath(r.URL.Path)
+
+	var matchedHandler http.HandlerFunc
+	methodMismatch := false
+
+	for _, rte := range rt.routes {
+		vars, ok := match(rte.segments, reqSegments)
+		if !ok {
+			continue
+		}
+		if rte.method != r.Method {
+			methodMismatch = true
+			continue
+		}
+		matchedHandler = rte.handler
+		ctx := context.WithValue(r.Context(), patternKey{}, rte.pattern)
+		if len(vars) > 0 {
+			ctx = context.WithValue(ctx, varsKey{}, vars)
+		}
+		r = r.WithContext(ctx)
+		break
+	}
+
+	final := rt.chain(func(w http.ResponseWriter, r *http.Request) {
+		if matchedHandler != nil {
+			matchedHandler(w, r)
+			return
+		}
+		if methodMismatch {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		rt.notFound(w, r)
+	})
+
+	final.ServeHTTP(w, r)
+}
+
+// chain wraps handler with the router's middleware, outermost first
+func (rt *Router) chain(handler http.HandlerFunc) http.Handler {
+	var h http.Handler = handler
+	for i := len(rt.middleware) - 1; i >= 0; i-- {
+		h = rt.middleware[i](h)
+	}
+	return h
+}
+
+// Vars returns the path parameters captured for the matched route
+func Vars(r *http.Request) map[string]string {
+	vars, _ := r.Context().Value(varsKey{}).(map[string]string)
+	if vars == nil {
+		return map[string]string{}
+	}
+	return vars
+}
+
+// RoutePattern returns the path pattern of the route that matched this
+// request (e.g. "/api/dogs/{id}"), or "" if no route matched
+func RoutePattern(r *http.Request) string {
+	pattern, _ := r.Context().Value(patternKey{}).(string)
+	return pattern
+}
+
+func splitPath(path string) []string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+func match(pattern, path []string) (map[string]string, bool) {
+	if len(pattern) != len(path) {
+		return nil, false
+	}
+
+	var vars map[string]string
+	for i, seg := range pattern {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if vars == nil {
+				vars = make(map[string]string)
+			}
+			vars[seg[1:len(seg)-1]] = path[i]
+			continue
+		}
+		if seg != path[i] {
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
@@ -0,0 +1,226 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go_server/metrics"
+	"github.com/go_server/router"
+)
+
+type contextKey string
+
+// RequestIDKey is the context key under which RequestIDMiddleware stores the
+// generated request ID
+const RequestIDKey contextKey = "requestID"
+
+// statusRecorder wraps http.ResponseWriter to capture the status code and
+// byte count written by downstream handlers, since http.ResponseWriter
+// doesn't expose either
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += n
+	return n, err
+}
+
+// RequestIDFromContext returns the request ID stored by RequestIDMiddleware,
+// or "" if none is present
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(RequestIDKey).(string)
+	return id
+}
+
+// RequestIDMiddleware takes the request ID from the incoming X-Request-ID
+// header, or generates a UUID when that header is absent, storing it in the
+// request's context and echoing it back via the X-Request-ID header
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if id == "" {
+			id = newRequestID()
+		}
+
+		ctx := context.WithValue(r.Context(), RequestIDKey, id)
+		w.Header().Set("X-Request-ID", id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestLogger writes LoggingMiddleware's access log as JSON lines to stderr
+var requestLogger = slog.New(slog.NewJSONHandler(os.Stderr, nil))
+
+// LoggingMiddleware emits one structured JSON log line per request, with
+// fields ts, method, path, status, duration_ms, request_id, and remote_addr
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		requestLogger.Info("request",
+			"ts", start.UTC().Format(time.RFC3339Nano),
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"request_id", RequestIDFromContext(r.Context()),
+			"remote_addr", r.RemoteAddr,
+		)
+	})
+}
+
+// MetricsMiddleware records http_requests_total and
+// http_request_duration_seconds for every request, labeled by the matched
+// route pattern (e.g. "/api/dogs/{id}") rather than the realized path, so
+// distinct IDs don't each create their own time series
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		path := router.RoutePattern(r)
+		if path == "" {
+			path = "unmatched"
+		}
+
+		duration := time.Since(start).Seconds()
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, path).Observe(duration)
+		metrics.HTTPRequestsTotal.WithLabelValues(r.Method, path, strconv.Itoa(rec.status)).Inc()
+	})
+}
+
+// RecoveryMiddleware recovers from panics in downstream handlers, logging
+// the panic and returning a 500 instead of crashing the server
+func RecoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Printf("panic handling %s %s: %v", r.Method, r.URL.Path, err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CORSMiddleware adds permissive CORS headers suitable for the public API,
+// short-circuiting preflight OPTIONS requests
+func CORSMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// jsonExemptRoutes lists route patterns that don't produce JSON and so must
+// bypass ContentNegotiationMiddleware's Accept-header check, e.g. /metrics,
+// which serves the Prometheus text exposition format
+var jsonExemptRoutes = map[string]bool{
+	"/metrics": true,
+}
+
+// ContentNegotiationMiddleware rejects requests that explicitly ask for a
+// response format other than JSON via the Accept header
+func ContentNegotiationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if jsonExemptRoutes[router.RoutePattern(r)] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !acceptsJSON(r.Header.Get("Accept")) {
+			http.Error(w, "Not Acceptable: this API only produces application/json", http.StatusNotAcceptable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acceptsJSON reports whether an Accept header permits an application/json
+// response. It's a minimal RFC 7231 media-range parser: a request accepts
+// JSON if any comma-separated range with a non-zero q value is "*/*",
+// "application/*", or "application/json" — real clients like Prometheus send
+// multi-range headers (e.g. "application/openmetrics-text;version=1.0.0,
+// text/plain;version=0.0.4;q=0.5,*/*;q=0.1") that plain string equality
+// rejects even though they do accept JSON.
+func acceptsJSON(accept string) bool {
+	if accept == "" {
+		return true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, q := parseMediaRange(part)
+		if q == 0 {
+			continue
+		}
+		switch mediaType {
+		case "*/*", "application/*", "application/json":
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseMediaRange splits a single Accept media range into its type and
+// q value, defaulting q to 1 when absent or unparsable
+func parseMediaRange(part string) (mediaType string, q float64) {
+	q = 1
+	for i, seg := range strings.Split(part, ";") {
+		seg = strings.TrimSpace(seg)
+		if i == 0 {
+			mediaType = seg
+			continue
+		}
+		name, value, ok := strings.Cut(seg, "=")
+		if ok && strings.TrimSpace(name) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+	}
+	return mediaType, q
+}
+
+// newRequestID generates a random v4-style UUID without pulling in an
+// external dependency
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "00000000-0000-0000-0000-000000000000"
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
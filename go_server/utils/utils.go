@@ -4,39 +4,28 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
-	"strings"
+
+	"github.com/go_server/models"
 )
 
 // JSONResponse sends a JSON response with the given status code and data
 func JSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
-	
+
 	if err := json.NewEncoder(w).Encode(data); err != nil {
 		log.Printf("Error encoding JSON response: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
 
-// LoggingMiddleware logs HTTP requests
-func LoggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("%s %s %s", r.RemoteAddr, r.Method, r.URL.Path)
-		next.ServeHTTP(w, r)
+// WriteError sends a structured JSON error response. code is a short
+// machine-readable identifier (e.g. "validation_error", "not_found"); fields
+// carries field-level validation errors and may be nil.
+func WriteError(w http.ResponseWriter, statusCode int, code, message string, fields map[string]string) {
+	JSONResponse(w, statusCode, models.ErrorResponse{
+		Error:  message,
+		Code:   code,
+		Fields: fields,
 	})
-}
-
-// ExtractIDFromPath extracts the ID from a path like /api/resource/{id}
-func ExtractIDFromPath(path, prefix string) (string, bool) {
-	if !strings.HasPrefix(path, prefix) {
-		return "", false
-	}
-	
-	idPart := path[len(prefix):]
-	if idPart == "" {
-		return "", false
-	}
-	
-	// Remove any trailing slash
-	return strings.TrimSuffix(idPart, "/"), true
 }
\ No newline at end of file